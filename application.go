@@ -0,0 +1,153 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+// Application is the definition for an application in marathon
+type Application struct {
+	ID                    string                `json:"id,omitempty"`
+	Cmd                   *string               `json:"cmd,omitempty"`
+	Args                  *[]string             `json:"args,omitempty"`
+	Constraints           *[][]string           `json:"constraints,omitempty"`
+	CPUs                  float64               `json:"cpus,omitempty"`
+	Disk                  *float64              `json:"disk,omitempty"`
+	Mem                   *float64              `json:"mem,omitempty"`
+	Instances             *int                  `json:"instances,omitempty"`
+	Ports                 []int                 `json:"ports,omitempty"`
+	RequirePorts          *bool                 `json:"requirePorts,omitempty"`
+	BackoffSeconds        *float64              `json:"backoffSeconds,omitempty"`
+	BackoffFactor         *float64              `json:"backoffFactor,omitempty"`
+	MaxLaunchDelaySeconds *float64              `json:"maxLaunchDelaySeconds,omitempty"`
+	Env                   *map[string]string    `json:"env,omitempty"`
+	Labels                *map[string]string    `json:"labels,omitempty"`
+	HealthChecks          *[]HealthCheck        `json:"healthChecks,omitempty"`
+	Dependencies          []string              `json:"dependencies,omitempty"`
+	UnreachableStrategy   *UnreachableStrategy  `json:"unreachableStrategy,omitempty"`
+	KillSelection         KillSelection         `json:"killSelection,omitempty"`
+	Version               string                `json:"version,omitempty"`
+	TasksRunning          int                   `json:"tasksRunning,omitempty"`
+	TasksStaged           int                   `json:"tasksStaged,omitempty"`
+	TasksHealthy          int                   `json:"tasksHealthy,omitempty"`
+	TasksUnhealthy        int                   `json:"tasksUnhealthy,omitempty"`
+	Tasks                 []*Task               `json:"tasks,omitempty"`
+	TaskStats             *ApplicationTaskStats `json:"taskStats,omitempty"`
+}
+
+// ApplicationTaskStats holds the per-app task statistics returned when an application is
+// fetched with the EmbedAppsTaskStats embed option
+type ApplicationTaskStats struct {
+	TotalSummary            *TaskStats `json:"totalSummary,omitempty"`
+	StartedAfterLastScaling *TaskStats `json:"startedAfterLastScaling,omitempty"`
+	WithLatestConfig        *TaskStats `json:"withLatestConfig,omitempty"`
+}
+
+// TaskStats is a single task-statistics grouping within ApplicationTaskStats
+type TaskStats struct {
+	Stats TaskStatsDetail `json:"stats"`
+}
+
+// TaskStatsDetail carries the running/staged counts and life-time percentiles for a grouping
+type TaskStatsDetail struct {
+	Counts   TaskStatsCounts   `json:"counts"`
+	LifeTime TaskStatsLifeTime `json:"lifeTime"`
+}
+
+// TaskStatsCounts is the running/staged task counts for a TaskStats grouping
+type TaskStatsCounts struct {
+	Staged  int `json:"staged"`
+	Running int `json:"running"`
+}
+
+// TaskStatsLifeTime is the average/median task uptime (in seconds) for a TaskStats grouping
+type TaskStatsLifeTime struct {
+	AverageSeconds float64 `json:"averageSeconds"`
+	MedianSeconds  float64 `json:"medianSeconds"`
+}
+
+// HealthCheck is the definition for an application health check
+type HealthCheck struct {
+	Path                   string `json:"path,omitempty"`
+	Protocol               string `json:"protocol,omitempty"`
+	PortIndex              *int   `json:"portIndex,omitempty"`
+	GracePeriodSeconds     int    `json:"gracePeriodSeconds,omitempty"`
+	IntervalSeconds        int    `json:"intervalSeconds,omitempty"`
+	TimeoutSeconds         int    `json:"timeoutSeconds,omitempty"`
+	MaxConsecutiveFailures int    `json:"maxConsecutiveFailures,omitempty"`
+}
+
+// KillSelection controls which of an application's tasks are killed first
+// when an operation (scale down, restart) needs to remove instances
+type KillSelection string
+
+const (
+	// YoungestFirst kills the most recently launched tasks first
+	YoungestFirst KillSelection = "YoungestFirst"
+	// OldestFirst kills the longest running tasks first
+	OldestFirst KillSelection = "OldestFirst"
+)
+
+// NewDockerApplication creates a default application which can be customized via the fluent setters below
+func NewDockerApplication() *Application {
+	return &Application{}
+}
+
+// Name sets the id of the application
+func (r *Application) Name(id string) *Application {
+	r.ID = id
+	return r
+}
+
+// Command sets the application command
+func (r *Application) Command(cmd string) *Application {
+	r.Cmd = &cmd
+	return r
+}
+
+// CPU sets the amount of CPU shares to assign to the application
+func (r *Application) CPU(cpu float64) *Application {
+	r.CPUs = cpu
+	return r
+}
+
+// Memory sets the amount of memory (MB) to assign to the application
+func (r *Application) Memory(memory float64) *Application {
+	r.Mem = &memory
+	return r
+}
+
+// Count sets the number of instances of the application to run
+func (r *Application) Count(count int) *Application {
+	r.Instances = &count
+	return r
+}
+
+// AddEnv adds an environment variable to the application
+func (r *Application) AddEnv(key, value string) *Application {
+	if r.Env == nil {
+		r.Env = &map[string]string{}
+	}
+	(*r.Env)[key] = value
+	return r
+}
+
+// AddLabel adds a label to the application
+func (r *Application) AddLabel(key, value string) *Application {
+	if r.Labels == nil {
+		r.Labels = &map[string]string{}
+	}
+	(*r.Labels)[key] = value
+	return r
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Applications is a collection of applications as returned by the v2/apps endpoint
+type Applications struct {
+	Apps []Application `json:"apps"`
+}
+
+// DeploymentID is the identifier marathon hands back for an asynchronous deployment
+type DeploymentID struct {
+	DeploymentID string `json:"deploymentId"`
+	Version      string `json:"version"`
+}
+
+// HasApplication checks whether an application exists in marathon
+func (r *marathonClient) HasApplication(name string) (bool, error) {
+	return r.hasApplicationCtx(context.Background(), name)
+}
+
+// hasApplicationCtx is the context-aware implementation shared by HasApplication and
+// WaitOnApplicationCtx, so a cancelled/expired ctx aborts the in-flight HTTP read
+// instead of only interrupting the poll ticker between attempts
+func (r *marathonClient) hasApplicationCtx(ctx context.Context, name string) (bool, error) {
+	applications, err := r.ApplicationsCtx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	for _, application := range applications.Apps {
+		if application.ID == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Applications retrieves the list of applications registered with marathon. Pass one or more
+// EmbedOption values (e.g. EmbedAppsTaskStats) to inline extra per-application detail.
+func (r *marathonClient) Applications(v url.Values, embeds ...EmbedOption) (*Applications, error) {
+	return r.ApplicationsCtx(context.Background(), v, embeds...)
+}
+
+// ApplicationsCtx is the context-aware sibling of Applications
+func (r *marathonClient) ApplicationsCtx(ctx context.Context, v url.Values, embeds ...EmbedOption) (*Applications, error) {
+	v = addEmbeds(v, embeds)
+
+	uri := marathonAPIApps
+	if v != nil {
+		uri = fmt.Sprintf("%s?%s", uri, v.Encode())
+	}
+
+	applications := new(Applications)
+	if err := r.apiGetCtx(ctx, uri, nil, applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+// Application retrieves a single application by name. Pass one or more EmbedOption values
+// (e.g. EmbedAppsTaskStats) to inline extra detail such as per-app task statistics.
+func (r *marathonClient) Application(name string, embeds ...EmbedOption) (*Application, error) {
+	return r.ApplicationCtx(context.Background(), name, embeds...)
+}
+
+// ApplicationCtx is the context-aware sibling of Application
+func (r *marathonClient) ApplicationCtx(ctx context.Context, name string, embeds ...EmbedOption) (*Application, error) {
+	v := addEmbeds(nil, embeds)
+
+	uri := fmt.Sprintf("%s/%s", marathonAPIApps, name)
+	if v != nil {
+		uri = uri + "?" + v.Encode()
+	}
+
+	application := new(applicationWrapper)
+	if err := r.apiGetCtx(ctx, uri, nil, application); err != nil {
+		return nil, err
+	}
+	return &application.Application, nil
+}
+
+// applicationWrapper mirrors the {"app": {...}} envelope marathon wraps a single application in
+type applicationWrapper struct {
+	Application Application `json:"app"`
+}
+
+// CreateApplication creates a new application in marathon
+func (r *marathonClient) CreateApplication(application *Application) (*Application, error) {
+	return r.CreateApplicationCtx(context.Background(), application)
+}
+
+// CreateApplicationCtx is the context-aware sibling of CreateApplication
+func (r *marathonClient) CreateApplicationCtx(ctx context.Context, application *Application) (*Application, error) {
+	result := new(Application)
+	if err := r.apiPostCtx(ctx, marathonAPIApps, application, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteApplication deletes an application from marathon
+func (r *marathonClient) DeleteApplication(name string) (*DeploymentID, error) {
+	deployment := new(DeploymentID)
+	if err := r.apiDelete(fmt.Sprintf("%s/%s", marathonAPIApps, name), nil, deployment); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// UpdateApplication updates an existing application in marathon
+func (r *marathonClient) UpdateApplication(application *Application) (*DeploymentID, error) {
+	deployment := new(DeploymentID)
+	if err := r.apiPut(fmt.Sprintf("%s/%s", marathonAPIApps, application.ID), application, deployment); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// WaitOnApplication waits for an application to become available, or until timeout elapses
+func (r *marathonClient) WaitOnApplication(name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return r.WaitOnApplicationCtx(ctx, name)
+}
+
+// WaitOnApplicationCtx is the context-aware sibling of WaitOnApplication; it polls until the
+// application is listed by marathon or ctx is cancelled/expires (task health is not checked)
+func (r *marathonClient) WaitOnApplicationCtx(ctx context.Context, name string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		found, err := r.hasApplicationCtx(ctx, name)
+		if err == nil && found {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrTimeoutError
+		case <-ticker.C:
+		}
+	}
+}
@@ -18,11 +18,11 @@ package marathon
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"sync"
@@ -57,19 +57,27 @@ type Marathon interface {
 	ScaleApplicationInstances(name string, instances int, force bool) (*DeploymentID, error)
 	// restart an application
 	RestartApplication(name string, force bool) (*DeploymentID, error)
-	// get a list of applications from marathon
-	Applications(url.Values) (*Applications, error)
-	// get a specific application
-	Application(name string) (*Application, error)
+	// get a list of applications from marathon, optionally inlining extra detail via EmbedOption
+	Applications(v url.Values, embeds ...EmbedOption) (*Applications, error)
+	// context-aware sibling of Applications
+	ApplicationsCtx(ctx context.Context, v url.Values, embeds ...EmbedOption) (*Applications, error)
+	// get a specific application, optionally inlining extra detail via EmbedOption
+	Application(name string, embeds ...EmbedOption) (*Application, error)
+	// context-aware sibling of Application
+	ApplicationCtx(ctx context.Context, name string, embeds ...EmbedOption) (*Application, error)
+	// context-aware sibling of CreateApplication
+	CreateApplicationCtx(ctx context.Context, application *Application) (*Application, error)
 	// wait of application
 	WaitOnApplication(name string, timeout time.Duration) error
+	// context-aware sibling of WaitOnApplication, cancelled/expired via ctx instead of a duration
+	WaitOnApplicationCtx(ctx context.Context, name string) error
 
 	// -- TASKS ---
 
 	// get a list of tasks for a specific application
 	Tasks(application string) (*Tasks, error)
-	// get a list of all tasks
-	AllTasks(v url.Values) (*Tasks, error)
+	// get a list of all tasks, optionally inlining extra detail via EmbedOption
+	AllTasks(v url.Values, embeds ...EmbedOption) (*Tasks, error)
 	// get the endpoints for a service on a application
 	TaskEndpoints(name string, port int, healthCheck bool) ([]string, error)
 	// kill all the tasks for any application
@@ -81,10 +89,12 @@ type Marathon interface {
 
 	// --- GROUPS ---
 
-	// list all the groups in the system
-	Groups() (*Groups, error)
-	// retrieve a specific group from marathon
-	Group(name string) (*Group, error)
+	// list all the groups in the system, optionally inlining extra detail via EmbedOption
+	Groups(embeds ...EmbedOption) (*Groups, error)
+	// retrieve a specific group from marathon, optionally inlining extra detail via EmbedOption
+	Group(name string, embeds ...EmbedOption) (*Group, error)
+	// context-aware sibling of Group
+	GroupCtx(ctx context.Context, name string, embeds ...EmbedOption) (*Group, error)
 	// create a group deployment
 	CreateGroup(group *Group) error
 	// delete a group
@@ -95,17 +105,23 @@ type Marathon interface {
 	HasGroup(name string) (bool, error)
 	// wait for an group to be deployed
 	WaitOnGroup(name string, timeout time.Duration) error
+	// context-aware sibling of WaitOnGroup, cancelled/expired via ctx instead of a duration
+	WaitOnGroupCtx(ctx context.Context, name string) error
 
 	// --- DEPLOYMENTS ---
 
 	// get a list of the deployments
 	Deployments() ([]*Deployment, error)
+	// context-aware sibling of Deployments
+	DeploymentsCtx(ctx context.Context) ([]*Deployment, error)
 	// delete a deployment
 	DeleteDeployment(id string, force bool) (*DeploymentID, error)
 	// check to see if a deployment exists
 	HasDeployment(id string) (bool, error)
 	// wait of a deployment to finish
 	WaitOnDeployment(id string, timeout time.Duration) error
+	// context-aware sibling of WaitOnDeployment, cancelled/expired via ctx instead of a duration
+	WaitOnDeploymentCtx(ctx context.Context, id string) error
 
 	// --- SUBSCRIPTIONS ---
 
@@ -117,6 +133,8 @@ type Marathon interface {
 	RemoveEventsListener(channel EventsChannel)
 	// remove our self from subscriptions
 	Unsubscribe(string) error
+	// a channel on which transient subscription failures (failovers, dropped streams) are reported
+	SubscriptionsErrorChan() <-chan error
 
 	// --- MISC ---
 
@@ -130,6 +148,11 @@ type Marathon interface {
 	Leader() (string, error)
 	// cause the current leader to abdicate
 	AbdicateLeader() (string, error)
+	// retrieve the up/down status of every known cluster member
+	ClusterMembers() []MemberStatus
+	// Close releases the resources held by the client: it stops the cluster's
+	// background health checker and tears down any active event subscription
+	Close()
 }
 
 var (
@@ -165,25 +188,33 @@ type marathonClient struct {
 	cluster Cluster
 	// a map of service you wish to listen to
 	listeners map[EventsChannel]int
+	// a channel used to report transient subscription failures to callers
+	eventsErrorChan chan error
+	// cancels the currently in-flight subscription (SSE read or callback listener) when the
+	// last listener unsubscribes, instead of waiting for the next reconnect attempt
+	subscriptionCancel context.CancelFunc
 }
 
 // NewClient creates a new marathon client
-//		config:			the configuration to use
+//
+//	config:			the configuration to use
 func NewClient(config Config) (Marathon, error) {
-	// step: we parse the url and build a cluster
-	cluster, err := newCluster(config.URL)
-	if err != nil {
-		return nil, err
-	}
-
 	service := new(marathonClient)
 	service.config = config
 	service.listeners = make(map[EventsChannel]int, 0)
-	service.cluster = cluster
+	service.eventsErrorChan = make(chan error)
 	service.httpClient = &http.Client{
 		Timeout: (time.Duration(config.RequestTimeout) * time.Second),
 	}
 
+	// step: we parse the url and build a cluster, with a background health
+	// checker that restores down members once they start responding again
+	cluster, err := newCluster(service.httpClient, config.URL, config.HealthCheckInterval, config.MemberFailureThreshold)
+	if err != nil {
+		return nil, err
+	}
+	service.cluster = cluster
+
 	return service, nil
 }
 
@@ -192,6 +223,31 @@ func (r *marathonClient) GetMarathonURL() string {
 	return r.cluster.URL()
 }
 
+// ClusterMembers retrieves the up/down status of every known cluster member
+func (r *marathonClient) ClusterMembers() []MemberStatus {
+	return r.cluster.Members()
+}
+
+// Close releases the resources held by the client. It cancels any in-flight
+// event subscription, shuts down the callback listener if one was started,
+// and stops the cluster's background health checker. Close is safe to call
+// even if no subscription was ever established.
+func (r *marathonClient) Close() {
+	r.Lock()
+	cancel := r.subscriptionCancel
+	eventsHTTP := r.eventsHTTP
+	r.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	if eventsHTTP != nil {
+		eventsHTTP.Close()
+	}
+
+	r.cluster.Close()
+}
+
 // Ping pings the current marathon endpoint (note, this is not a ICMP ping, but a rest api call)
 func (r *marathonClient) Ping() (bool, error) {
 	if err := r.apiGet(marathonAPIPing, nil, nil); err != nil {
@@ -202,22 +258,41 @@ func (r *marathonClient) Ping() (bool, error) {
 
 // TODO remove post, this is a GET request!
 func (r *marathonClient) apiGet(uri string, post, result interface{}) error {
-	return r.apiCall("GET", uri, post, result)
+	return r.apiCall(context.Background(), "GET", uri, post, result)
 }
 
 func (r *marathonClient) apiPut(uri string, post, result interface{}) error {
-	return r.apiCall("PUT", uri, post, result)
+	return r.apiCall(context.Background(), "PUT", uri, post, result)
 }
 
 func (r *marathonClient) apiPost(uri string, post, result interface{}) error {
-	return r.apiCall("POST", uri, post, result)
+	return r.apiCall(context.Background(), "POST", uri, post, result)
 }
 
 func (r *marathonClient) apiDelete(uri string, post, result interface{}) error {
-	return r.apiCall("DELETE", uri, post, result)
+	return r.apiCall(context.Background(), "DELETE", uri, post, result)
 }
 
-func (r *marathonClient) apiCall(method, uri string, body, result interface{}) error {
+func (r *marathonClient) apiGetCtx(ctx context.Context, uri string, post, result interface{}) error {
+	return r.apiCall(ctx, "GET", uri, post, result)
+}
+
+func (r *marathonClient) apiPutCtx(ctx context.Context, uri string, post, result interface{}) error {
+	return r.apiCall(ctx, "PUT", uri, post, result)
+}
+
+func (r *marathonClient) apiPostCtx(ctx context.Context, uri string, post, result interface{}) error {
+	return r.apiCall(ctx, "POST", uri, post, result)
+}
+
+func (r *marathonClient) apiDeleteCtx(ctx context.Context, uri string, post, result interface{}) error {
+	return r.apiCall(ctx, "DELETE", uri, post, result)
+}
+
+// apiCall performs a single request against a cluster member. The supplied context governs
+// the entire round trip: an expired or cancelled ctx aborts connection setup and unblocks
+// an in-flight response read immediately.
+func (r *marathonClient) apiCall(ctx context.Context, method, uri string, body, result interface{}) error {
 	// Get a member from the cluster
 	marathon, err := r.cluster.GetMember()
 	if err != nil {
@@ -235,7 +310,7 @@ func (r *marathonClient) apiCall(method, uri string, body, result interface{}) e
 	}
 
 	// Make the http request to Marathon
-	request, err := http.NewRequest(method, url, bytes.NewReader(jsonBody))
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(jsonBody))
 	if err != nil {
 		return err
 	}
@@ -247,23 +322,55 @@ func (r *marathonClient) apiCall(method, uri string, body, result interface{}) e
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("Accept", "application/json")
 
+	if r.config.RequestHook != nil {
+		// hand the hook a redacted clone so a hook that logs/exports request.Header (a
+		// perfectly natural thing to do for metrics/tracing) never sees the real credentials
+		redacted := request.Clone(ctx)
+		redacted.Header = redactHeaders(request.Header)
+		r.config.RequestHook(redacted)
+	}
+
+	started := time.Now()
 	response, err := r.httpClient.Do(request)
+	duration := time.Since(started)
 	if err != nil {
+		if r.config.ResponseHook != nil {
+			r.config.ResponseHook(nil, nil, duration, err)
+		}
+		r.cluster.MarkDown(marathon)
 		return err
 	}
 	defer response.Body.Close()
 
+	if r.config.ResponseHook != nil {
+		// the hook can reach the request through response.Request, so swap in a redacted clone
+		hookRequest := response.Request.Clone(ctx)
+		hookRequest.Header = redactHeaders(response.Request.Header)
+		response.Request = hookRequest
+	}
+
 	respBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
+		if r.config.ResponseHook != nil {
+			r.config.ResponseHook(response, nil, duration, err)
+		}
 		return err
 	}
 
-	log.Printf("apiCall(): %v %v (body: %s) returned [%v] %s\n", request.Method, request.URL.String(), jsonBody, response.Status, respBody)
+	if r.config.ResponseHook != nil {
+		r.config.ResponseHook(response, respBody, duration, nil)
+	}
+
+	if r.config.LogHTTPBodies {
+		r.logger().Debugf("apiCall(): %v %v headers=%v (body: %s) returned [%v] %s", request.Method, request.URL.String(), redactHeaders(request.Header), jsonBody, response.Status, respBody)
+	} else {
+		r.logger().Debugf("apiCall(): %v %v headers=%v returned [%v]", request.Method, request.URL.String(), redactHeaders(request.Header), response.Status)
+	}
 
 	if response.StatusCode >= 200 && response.StatusCode <= 299 {
 		if result != nil {
 			if err := json.Unmarshal(respBody, result); err != nil {
-				log.Printf("apiCall(): failed to unmarshall the response from marathon, error: %s\n", err)
+				r.logger().Errorf("apiCall(): failed to unmarshall the response from marathon, error: %s", err)
 				return ErrInvalidResponse
 			}
 		}
@@ -276,9 +383,10 @@ func (r *marathonClient) apiCall(method, uri string, body, result interface{}) e
 		return ErrConflict
 
 	} else if response.StatusCode >= 500 {
+		r.cluster.MarkDown(marathon)
 		return ErrInvalidResponse
 	}
 
-	log.Printf("apiCall(): unknown error: %s", respBody)
+	r.logger().Errorf("apiCall(): unknown error: %s", respBody)
 	return ErrInvalidResponse
 }
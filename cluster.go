@@ -0,0 +1,254 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemberStatus is a point-in-time snapshot of a cluster member's health as tracked by Cluster
+type MemberStatus struct {
+	// Member is the base url of the marathon member
+	Member string
+	// Healthy is true if the member is currently considered reachable
+	Healthy bool
+	// Failures is the number of failures recorded for the member since it was last restored
+	// by the health checker; successful requests do not reset it
+	Failures int
+}
+
+// Cluster is responsible for handing out the members of a marathon cluster and
+// tracking which of them are currently reachable
+type Cluster interface {
+	// URL returns the url used to build this cluster
+	URL() string
+	// GetMember returns a healthy marathon member to talk to
+	GetMember() (string, error)
+	// MarkDown marks the given member as unreachable
+	MarkDown(member string)
+	// Members returns the current up/down status of every known member
+	Members() []MemberStatus
+	// Close stops the background health checker
+	Close()
+}
+
+// member tracks the health of a single marathon cluster node
+type member struct {
+	// url is the base url of the member, e.g. http://10.0.0.1:8080
+	url string
+	// down is true while the member is excluded from rotation
+	down bool
+	// failures counts MarkDown calls since the member was last restored and drives its backoff
+	failures int
+	// retryAt is the earliest time the health checker should re-probe a down member
+	retryAt time.Time
+}
+
+// cluster is the default implementation of the Cluster interface
+type cluster struct {
+	sync.Mutex
+	// the original url used to build the cluster
+	url string
+	// the full list of known members
+	members []*member
+	// the index of the last member handed out, used for simple round robin
+	index int
+	// httpClient is used by the background health checker to ping down members
+	httpClient *http.Client
+	// failureThreshold is how many MarkDown calls take a member out of rotation; the count
+	// is only reset when the health checker restores the member
+	failureThreshold int
+	// stopCh stops the background health checker goroutine on Close
+	stopCh chan struct{}
+	// closeOnce guards stopCh so repeat Close() calls don't panic
+	closeOnce sync.Once
+}
+
+// newCluster creates a new cluster from a comma separated list of marathon endpoints and,
+// when healthCheckInterval is positive, starts a background health checker which restores
+// down members once they start responding to marathonAPIPing again
+func newCluster(httpClient *http.Client, url string, healthCheckInterval time.Duration, failureThreshold int) (Cluster, error) {
+	if url == "" {
+		return nil, ErrInvalidEndpoint
+	}
+
+	var members []*member
+	for _, m := range strings.Split(url, ",") {
+		m = strings.TrimSpace(m)
+		m = strings.TrimSuffix(m, "/")
+		if m == "" {
+			continue
+		}
+		members = append(members, &member{url: m})
+	}
+	if len(members) == 0 {
+		return nil, ErrInvalidEndpoint
+	}
+
+	if failureThreshold <= 0 {
+		failureThreshold = 1
+	}
+
+	c := &cluster{
+		url:              url,
+		members:          members,
+		httpClient:       httpClient,
+		failureThreshold: failureThreshold,
+		stopCh:           make(chan struct{}),
+	}
+
+	if healthCheckInterval > 0 {
+		go c.runHealthChecker(healthCheckInterval)
+	}
+
+	return c, nil
+}
+
+// URL returns the url originally used to build the cluster
+func (c *cluster) URL() string {
+	return c.url
+}
+
+// GetMember returns the next healthy member in the rotation
+func (c *cluster) GetMember() (string, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	for i := 0; i < len(c.members); i++ {
+		m := c.members[c.index%len(c.members)]
+		c.index++
+		if !m.down {
+			return m.url, nil
+		}
+	}
+
+	return "", ErrMarathonDown
+}
+
+// MarkDown records a failure for the given member and, once failureThreshold is reached,
+// takes it out of rotation, delaying its next health check probe by backoffFor(failures)
+func (c *cluster) MarkDown(memberURL string) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, m := range c.members {
+		if m.url != memberURL {
+			continue
+		}
+		m.failures++
+		if m.failures >= c.failureThreshold {
+			m.down = true
+			m.retryAt = time.Now().Add(backoffFor(m.failures))
+		}
+		return
+	}
+}
+
+// Members returns the current up/down status of every known member
+func (c *cluster) Members() []MemberStatus {
+	c.Lock()
+	defer c.Unlock()
+
+	statuses := make([]MemberStatus, 0, len(c.members))
+	for _, m := range c.members {
+		statuses = append(statuses, MemberStatus{
+			Member:   m.url,
+			Healthy:  !m.down,
+			Failures: m.failures,
+		})
+	}
+	return statuses
+}
+
+// Close stops the background health checker. It is safe to call more than once.
+func (c *cluster) Close() {
+	c.closeOnce.Do(func() {
+		close(c.stopCh)
+	})
+}
+
+// runHealthChecker periodically pings down members and restores them to the
+// rotation the moment they start answering marathonAPIPing successfully
+func (c *cluster) runHealthChecker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.probeDownMembers()
+		}
+	}
+}
+
+func (c *cluster) probeDownMembers() {
+	c.Lock()
+	var toProbe []*member
+	now := time.Now()
+	for _, m := range c.members {
+		if m.down && !now.Before(m.retryAt) {
+			toProbe = append(toProbe, m)
+		}
+	}
+	c.Unlock()
+
+	for _, m := range toProbe {
+		if c.ping(m.url) {
+			c.Lock()
+			m.down = false
+			m.failures = 0
+			c.Unlock()
+		}
+	}
+}
+
+// ping issues a GET against member's marathonAPIPing endpoint, joining the base
+// url and the api path without risking a doubled slash
+func (c *cluster) ping(memberURL string) bool {
+	request, err := http.NewRequest("GET", joinURL(memberURL, marathonAPIPing), nil)
+	if err != nil {
+		return false
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return false
+	}
+	defer response.Body.Close()
+
+	return response.StatusCode == http.StatusOK
+}
+
+// joinURL joins a member base url and an api path with exactly one slash between them
+func joinURL(base, path string) string {
+	return strings.TrimSuffix(base, "/") + "/" + strings.TrimPrefix(path, "/")
+}
+
+// backoffFor computes a monotonically increasing backoff, capped at 2 minutes,
+// for the given number of consecutive failures
+func backoffFor(failures int) time.Duration {
+	backoff := time.Duration(failures) * 5 * time.Second
+	if cap := 2 * time.Minute; backoff > cap {
+		backoff = cap
+	}
+	return backoff
+}
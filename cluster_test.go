@@ -0,0 +1,162 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClusterGetMemberRoundRobin(t *testing.T) {
+	c, err := newCluster(http.DefaultClient, "http://10.0.0.1:8080,http://10.0.0.2:8080", 0, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+
+	seen := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		member, err := c.GetMember()
+		if err != nil {
+			t.Fatalf("GetMember() returned error: %s", err)
+		}
+		seen[member] = true
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected both members to be handed out in rotation, got: %v", seen)
+	}
+}
+
+func TestClusterGetMemberSkipsDownMembers(t *testing.T) {
+	c, err := newCluster(http.DefaultClient, "http://10.0.0.1:8080,http://10.0.0.2:8080", 0, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+
+	c.MarkDown("http://10.0.0.1:8080")
+
+	for i := 0; i < 4; i++ {
+		member, err := c.GetMember()
+		if err != nil {
+			t.Fatalf("GetMember() returned error: %s", err)
+		}
+		if member != "http://10.0.0.2:8080" {
+			t.Fatalf("expected the down member to be skipped, got: %s", member)
+		}
+	}
+}
+
+func TestClusterGetMemberAllDown(t *testing.T) {
+	c, err := newCluster(http.DefaultClient, "http://10.0.0.1:8080", 0, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+
+	c.MarkDown("http://10.0.0.1:8080")
+
+	if _, err := c.GetMember(); err != ErrMarathonDown {
+		t.Fatalf("expected ErrMarathonDown once every member is down, got: %v", err)
+	}
+}
+
+func TestClusterMarkDownRespectsFailureThreshold(t *testing.T) {
+	c, err := newCluster(http.DefaultClient, "http://10.0.0.1:8080", 0, 3)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+
+	c.MarkDown("http://10.0.0.1:8080")
+	c.MarkDown("http://10.0.0.1:8080")
+
+	if _, err := c.GetMember(); err != nil {
+		t.Fatalf("member should still be considered up below the failure threshold, got: %v", err)
+	}
+
+	c.MarkDown("http://10.0.0.1:8080")
+
+	if _, err := c.GetMember(); err != ErrMarathonDown {
+		t.Fatalf("member should be marked down once the failure threshold is reached, got: %v", err)
+	}
+}
+
+func TestClusterBackoffForIsMonotonicAndCapped(t *testing.T) {
+	if got := backoffFor(1); got != 5*time.Second {
+		t.Fatalf("backoffFor(1) = %s, want 5s", got)
+	}
+	if got := backoffFor(2); got != 10*time.Second {
+		t.Fatalf("backoffFor(2) = %s, want 10s", got)
+	}
+	if got := backoffFor(100); got != 2*time.Minute {
+		t.Fatalf("backoffFor(100) = %s, want the 2m cap", got)
+	}
+}
+
+func TestClusterProbeDownMembersRestoresOnSuccessfulPing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	raw, err := newCluster(http.DefaultClient, server.URL, 0, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+	c := raw.(*cluster)
+
+	c.MarkDown(server.URL)
+	if _, err := c.GetMember(); err != ErrMarathonDown {
+		t.Fatalf("expected the member to be marked down, got: %v", err)
+	}
+
+	// retryAt defaults to time.Now().Add(backoffFor(1)); force it open so the probe fires now
+	c.Lock()
+	c.members[0].retryAt = time.Now().Add(-time.Second)
+	c.Unlock()
+
+	c.probeDownMembers()
+
+	if member, err := c.GetMember(); err != nil || member != server.URL {
+		t.Fatalf("expected the member to be restored after a successful ping, got member=%q err=%v", member, err)
+	}
+}
+
+func TestClusterClose(t *testing.T) {
+	raw, err := newCluster(http.DefaultClient, "http://10.0.0.1:8080", time.Millisecond, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+	c := raw.(*cluster)
+
+	c.Close()
+
+	select {
+	case <-c.stopCh:
+	default:
+		t.Fatal("Close() should close stopCh so the background health checker stops")
+	}
+}
+
+func TestClusterCloseIsIdempotent(t *testing.T) {
+	raw, err := newCluster(http.DefaultClient, "http://10.0.0.1:8080", 0, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+
+	raw.Close()
+	raw.Close()
+}
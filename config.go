@@ -0,0 +1,84 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"net/http"
+	"time"
+)
+
+// EventsTransport is the method used by the client to receive event notifications from Marathon
+type EventsTransport int
+
+const (
+	// EventsTransportCallback registers a http callback with marathon and receives events on a local http server
+	EventsTransportCallback EventsTransport = iota
+	// EventsTransportSSE uses the marathon event stream (Server-Sent Events) endpoint
+	EventsTransportSSE
+)
+
+// Config is the configuration used to create a new marathon client
+type Config struct {
+	// URL is the url of the marathon endpoint, multiple members can be separated with a comma
+	URL string
+	// EventsTransport is the transport used to receive events, defaults to EventsTransportCallback
+	EventsTransport EventsTransport
+	// EventsInterface is the network interface to bind the callback listener on
+	EventsInterface string
+	// EventsPort is the port the callback listener listens on
+	EventsPort int
+	// CallbackURL is the url marathon should use when calling us back, required when behind a NAT / LB
+	CallbackURL string
+	// HTTPBasicAuthUser is the username used for basic auth, if any
+	HTTPBasicAuthUser string
+	// HTTPBasicPassword is the password used for basic auth, if any
+	HTTPBasicPassword string
+	// RequestTimeout is the timeout (in seconds) for requests made against marathon
+	RequestTimeout int
+	// HealthCheckInterval is how often down cluster members are re-probed via marathonAPIPing;
+	// zero disables the background health checker
+	HealthCheckInterval time.Duration
+	// MemberFailureThreshold is how many failures a member must accrue before being taken out
+	// of the GetMember rotation; successful requests do not reset the count, only a restore
+	// by the health checker does
+	MemberFailureThreshold int
+	// Logger receives structured debug/info/warn/error output from the client, defaults to a no-op
+	Logger Logger
+	// LogHTTPBodies gates whether request/response bodies are included in Logger output; off by
+	// default since bodies may carry credentials or other sensitive application configuration
+	LogHTTPBodies bool
+	// RequestHook, if set, is called with every REST API request just before it is sent, letting
+	// callers wire up metrics (e.g. a Prometheus histogram) or tracing spans. The SSE event
+	// stream, the callback subscription and the health checker's pings are not passed to it.
+	RequestHook func(*http.Request)
+	// ResponseHook, if set, is called after every REST API request completes (err is non-nil on
+	// transport failure) with the response, raw body and elapsed time, for the same
+	// metrics/tracing uses; it covers the same requests as RequestHook
+	ResponseHook func(response *http.Response, body []byte, duration time.Duration, err error)
+}
+
+// NewDefaultConfig returns a default configuration for the client
+func NewDefaultConfig() Config {
+	return Config{
+		EventsTransport:        EventsTransportCallback,
+		EventsPort:             10001,
+		RequestTimeout:         5,
+		HealthCheckInterval:    30 * time.Second,
+		MemberFailureThreshold: 3,
+		Logger:                 noopLogger{},
+	}
+}
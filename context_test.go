@@ -0,0 +1,130 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient builds a marathonClient against a test server, bypassing
+// NewClient since it does more than this package's *Ctx methods need.
+func newTestClient(t *testing.T, server *httptest.Server) *marathonClient {
+	t.Helper()
+
+	cluster, err := newCluster(server.Client(), server.URL, 0, 1)
+	if err != nil {
+		t.Fatalf("newCluster() returned error: %s", err)
+	}
+
+	return &marathonClient{
+		config:     NewDefaultConfig(),
+		httpClient: server.Client(),
+		cluster:    cluster,
+	}
+}
+
+func TestApplicationsCtxAbortsOnCancelledContext(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-unblock
+		w.Write([]byte(`{"apps":[]}`))
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	client := newTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.ApplicationsCtx(ctx, nil); err == nil {
+		t.Fatal("expected ApplicationsCtx to return an error for an already-cancelled context")
+	}
+}
+
+func TestHasApplicationCtxReturnsPromptlyOnContextTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-unblock
+		w.Write([]byte(`{"apps":[]}`))
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	client := newTestClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.hasApplicationCtx(ctx, "myapp")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected hasApplicationCtx to return an error once the context times out")
+	}
+	if elapsed > time.Second {
+		t.Fatalf("hasApplicationCtx took %s to return after a 20ms context timeout; it should abort the in-flight request", elapsed)
+	}
+}
+
+func TestWaitOnApplicationCtxReturnsOnceApplicationAppears(t *testing.T) {
+	var found atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if found.Load() {
+			w.Write([]byte(`{"apps":[{"id":"myapp"}]}`))
+			return
+		}
+		w.Write([]byte(`{"apps":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		found.Store(true)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.WaitOnApplicationCtx(ctx, "myapp"); err != nil {
+		t.Fatalf("WaitOnApplicationCtx() returned error: %s", err)
+	}
+}
+
+func TestWaitOnApplicationCtxTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"apps":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := client.WaitOnApplicationCtx(ctx, "myapp"); err != ErrTimeoutError {
+		t.Fatalf("WaitOnApplicationCtx() = %v, want ErrTimeoutError", err)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Deployment is a single in-flight marathon deployment
+type Deployment struct {
+	ID           string   `json:"id"`
+	Version      string   `json:"version"`
+	AffectedApps []string `json:"affectedApps"`
+}
+
+// Deployments retrieves the list of deployments currently in progress
+func (r *marathonClient) Deployments() ([]*Deployment, error) {
+	return r.DeploymentsCtx(context.Background())
+}
+
+// DeploymentsCtx is the context-aware sibling of Deployments
+func (r *marathonClient) DeploymentsCtx(ctx context.Context) ([]*Deployment, error) {
+	var deployments []*Deployment
+	if err := r.apiGetCtx(ctx, marathonAPIDeployments, nil, &deployments); err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+// DeleteDeployment cancels a deployment, optionally rolling it back (force=false) or
+// stopping it in place (force=true)
+func (r *marathonClient) DeleteDeployment(id string, force bool) (*DeploymentID, error) {
+	uri := fmt.Sprintf("%s/%s", marathonAPIDeployments, id)
+	if force {
+		uri += "?force=true"
+	}
+
+	deployment := new(DeploymentID)
+	if err := r.apiDelete(uri, nil, deployment); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// HasDeployment checks whether a deployment with the given id is currently in progress
+func (r *marathonClient) HasDeployment(id string) (bool, error) {
+	return r.hasDeploymentCtx(context.Background(), id)
+}
+
+// hasDeploymentCtx is the context-aware implementation shared by HasDeployment and
+// WaitOnDeploymentCtx, so a cancelled/expired ctx aborts the in-flight HTTP read
+// instead of only interrupting the poll ticker between attempts
+func (r *marathonClient) hasDeploymentCtx(ctx context.Context, id string) (bool, error) {
+	deployments, err := r.DeploymentsCtx(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, deployment := range deployments {
+		if deployment.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WaitOnDeployment blocks until the given deployment has finished, or timeout elapses
+func (r *marathonClient) WaitOnDeployment(id string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return r.WaitOnDeploymentCtx(ctx, id)
+}
+
+// WaitOnDeploymentCtx is the context-aware sibling of WaitOnDeployment; it polls until the
+// deployment disappears from the in-progress list or ctx is cancelled/expires
+func (r *marathonClient) WaitOnDeploymentCtx(ctx context.Context, id string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		found, err := r.hasDeploymentCtx(ctx, id)
+		if err == nil && !found {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrTimeoutError
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import "net/url"
+
+// EmbedOption is a typed value for the marathon "embed" query parameter, used to ask
+// the API to inline extra, normally-omitted detail on an application/group/task listing
+type EmbedOption string
+
+const (
+	// EmbedAppsTasks embeds the list of tasks on each application
+	EmbedAppsTasks EmbedOption = "apps.tasks"
+	// EmbedAppsCounts embeds the running/staged/healthy/unhealthy task counts on each application
+	EmbedAppsCounts EmbedOption = "apps.counts"
+	// EmbedAppsDeployments embeds the in-flight deployments affecting each application
+	EmbedAppsDeployments EmbedOption = "apps.deployments"
+	// EmbedAppsReadiness embeds readiness check results on each application
+	EmbedAppsReadiness EmbedOption = "apps.readiness"
+	// EmbedAppsLastTaskFailure embeds the last task failure seen for each application
+	EmbedAppsLastTaskFailure EmbedOption = "apps.lastTaskFailure"
+	// EmbedAppsTaskStats embeds per-app task statistics (uptime, running counts, life-time percentiles)
+	EmbedAppsTaskStats EmbedOption = "apps.taskStats"
+	// EmbedGroupsApps embeds the applications nested under each group
+	EmbedGroupsApps EmbedOption = "group.apps"
+)
+
+// addEmbeds appends one "embed" query parameter per embeds entry to v, creating v if nil
+func addEmbeds(v url.Values, embeds []EmbedOption) url.Values {
+	if len(embeds) == 0 {
+		return v
+	}
+	if v == nil {
+		v = url.Values{}
+	}
+	for _, embed := range embeds {
+		v.Add("embed", string(embed))
+	}
+	return v
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// EventsChannel is a channel used to stream events from marathon to a consumer
+type EventsChannel chan *Event
+
+// Event is a single notification received from the marathon event bus
+type Event struct {
+	// ID is the marathon event type, e.g. status_update_event
+	ID string
+	// Event is the raw, decoded event body
+	Event interface{}
+}
+
+// Subscriptions is a list of the current callback subscriptions held by marathon
+type Subscriptions struct {
+	CallbackURLs []string `json:"callbackUrls"`
+}
+
+const (
+	// sseRetryBaseInterval is the starting backoff between reconnect attempts
+	sseRetryBaseInterval = 500 * time.Millisecond
+	// sseRetryMaxInterval caps the exponential backoff between reconnect attempts
+	sseRetryMaxInterval = 30 * time.Second
+	// sseRetryMaxWindow bounds how long we keep retrying before giving up on a subscription
+	sseRetryMaxWindow = 5 * time.Minute
+	// callbackResubscribeInterval is how often we re-POST our callback subscription so we
+	// pick up a new leader after a marathon failover
+	callbackResubscribeInterval = 30 * time.Second
+)
+
+// Subscriptions returns the current marathon callback subscriptions
+func (r *marathonClient) Subscriptions() (*Subscriptions, error) {
+	subscriptions := new(Subscriptions)
+	if err := r.apiGet(marathonAPISubscription, nil, subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// SubscriptionsErrorChan returns a channel on which transient subscription
+// failures (failed-over members, exhausted retry windows) are reported. The
+// channel is unbuffered best-effort; slow consumers may miss errors.
+func (r *marathonClient) SubscriptionsErrorChan() <-chan error {
+	return r.eventsErrorChan
+}
+
+// AddEventsListener registers a channel to receive events, starting the underlying
+// subscription (callback or SSE) if this is the first listener. The filter bitmask is
+// stored with the channel but dispatch does not apply it.
+func (r *marathonClient) AddEventsListener(channel EventsChannel, filter int) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.listeners[channel] = filter
+
+	if !r.subscribedToSSE {
+		r.subscribedToSSE = true
+		go r.maintainSubscription()
+	}
+
+	return nil
+}
+
+// RemoveEventsListener removes a previously registered events channel. If this was the
+// last listener, the in-flight subscription (SSE read or callback listener) is cancelled
+// immediately rather than waiting for the next reconnect attempt to notice.
+func (r *marathonClient) RemoveEventsListener(channel EventsChannel) {
+	r.Lock()
+	delete(r.listeners, channel)
+	empty := len(r.listeners) == 0
+	cancel := r.subscriptionCancel
+	r.Unlock()
+
+	if empty && cancel != nil {
+		cancel()
+	}
+}
+
+// Unsubscribe removes our callback subscription from marathon
+func (r *marathonClient) Unsubscribe(callbackURL string) error {
+	return r.apiDelete(marathonAPISubscription+"?callbackUrl="+callbackURL, nil, nil)
+}
+
+// maintainSubscription keeps an event subscription alive for as long as there
+// are registered listeners, failing over to other cluster members and
+// reconnecting with an exponential backoff when the current member drops the
+// stream or refuses the subscription. The subscription's context is cancelled
+// the moment RemoveEventsListener empties the listener map, so an
+// open SSE read or callback listener never outlives its last caller.
+func (r *marathonClient) maintainSubscription() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Lock()
+	r.subscriptionCancel = cancel
+	r.Unlock()
+
+	defer func() {
+		cancel()
+		r.Lock()
+		r.subscriptionCancel = nil
+		r.subscribedToSSE = false
+		r.Unlock()
+	}()
+
+	backoff := sseRetryBaseInterval
+	windowStart := time.Now()
+
+	for {
+		r.RLock()
+		active := len(r.listeners) > 0
+		r.RUnlock()
+		if !active || ctx.Err() != nil {
+			return
+		}
+
+		member, err := r.cluster.GetMember()
+		if err != nil {
+			r.reportSubscriptionError(err)
+			return
+		}
+
+		switch r.config.EventsTransport {
+		case EventsTransportSSE:
+			err = r.runEventStream(ctx, member)
+		default:
+			err = r.subscribeCallback(ctx, member)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			backoff = sseRetryBaseInterval
+			windowStart = time.Now()
+			continue
+		}
+
+		r.cluster.MarkDown(member)
+		r.reportSubscriptionError(err)
+
+		if time.Since(windowStart) > sseRetryMaxWindow {
+			r.reportSubscriptionError(ErrMarathonDown)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jitter(backoff)):
+		}
+		backoff *= 2
+		if backoff > sseRetryMaxInterval {
+			backoff = sseRetryMaxInterval
+		}
+	}
+}
+
+// jitter randomises a backoff duration by +/- 50% to avoid thundering herds
+// of reconnecting clients against a recovering member
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d)))
+}
+
+// reportSubscriptionError pushes an error onto the SubscriptionsErrorChan
+// without blocking callers that aren't listening for it
+func (r *marathonClient) reportSubscriptionError(err error) {
+	select {
+	case r.eventsErrorChan <- err:
+	default:
+		r.logger().Warnf("maintainSubscription(): dropping subscription error, no listener: %s", err)
+	}
+}
+
+// runEventStream opens a SSE connection against the given member and streams
+// events to all registered listeners until the connection is dropped or ctx is cancelled.
+// It always returns a non-nil error, so a dropped stream counts as a member failure.
+func (r *marathonClient) runEventStream(ctx context.Context, member string) error {
+	request, err := http.NewRequestWithContext(ctx, "GET", member+"/"+marathonAPIEventStream, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return ErrInvalidResponse
+	}
+
+	var eventID string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			eventID = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			r.dispatch(&Event{ID: eventID, Event: data})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// subscribeCallback ensures our callback http listener is running, registers it with the
+// given member, and then blocks until ctx is cancelled or it's time to refresh the
+// subscription (picking up a new leader after a marathon failover)
+func (r *marathonClient) subscribeCallback(ctx context.Context, member string) error {
+	if err := r.ensureCallbackListener(); err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", joinURL(member, marathonAPISubscription+"?callbackUrl="+r.config.CallbackURL), nil)
+	if err != nil {
+		return err
+	}
+	if r.config.HTTPBasicAuthUser != "" {
+		request.SetBasicAuth(r.config.HTTPBasicAuthUser, r.config.HTTPBasicPassword)
+	}
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return ErrInvalidResponse
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-time.After(callbackResubscribeInterval):
+		return nil
+	}
+}
+
+// ensureCallbackListener starts the http.Server that receives marathon's callback POSTs,
+// binding it once to Config.EventsInterface:Config.EventsPort for the lifetime of the client
+func (r *marathonClient) ensureCallbackListener() error {
+	r.Lock()
+	defer r.Unlock()
+
+	if r.eventsHTTP != nil {
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", r.config.EventsInterface, r.config.EventsPort))
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", r.handleCallbackEvent)
+	server := &http.Server{Handler: mux}
+
+	r.eventsHTTP = server
+	go server.Serve(listener)
+
+	return nil
+}
+
+// handleCallbackEvent decodes a single event POSTed by marathon and fans it out to listeners
+func (r *marathonClient) handleCallbackEvent(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var envelope struct {
+		EventType string `json:"eventType"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	r.dispatch(&Event{ID: envelope.EventType, Event: json.RawMessage(body)})
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch fans an event out to every registered listener; the filters passed to
+// AddEventsListener are not applied, so every listener receives every event
+func (r *marathonClient) dispatch(event *Event) {
+	r.RLock()
+	defer r.RUnlock()
+
+	for channel := range r.listeners {
+		select {
+		case channel <- event:
+		default:
+			r.logger().Warnf("dispatch(): listener channel full, dropping event: %s", event.ID)
+		}
+	}
+}
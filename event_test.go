@@ -0,0 +1,103 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitterStaysWithinHalfToOneAndHalfTimesInput(t *testing.T) {
+	d := 10 * time.Second
+	for i := 0; i < 1000; i++ {
+		got := jitter(d)
+		if got < d/2 || got >= d+d/2 {
+			t.Fatalf("jitter(%s) = %s, want in [%s, %s)", d, got, d/2, d+d/2)
+		}
+	}
+}
+
+func TestJitterZeroIsZero(t *testing.T) {
+	if got := jitter(0); got != 0 {
+		t.Fatalf("jitter(0) = %s, want 0", got)
+	}
+}
+
+func TestSubscriptionsErrorChanReturnsConfiguredChannel(t *testing.T) {
+	r := &marathonClient{eventsErrorChan: make(chan error, 1)}
+
+	r.eventsErrorChan <- ErrMarathonDown
+
+	select {
+	case err := <-r.SubscriptionsErrorChan():
+		if err != ErrMarathonDown {
+			t.Fatalf("got %v, want ErrMarathonDown", err)
+		}
+	default:
+		t.Fatal("expected SubscriptionsErrorChan() to return the client's eventsErrorChan")
+	}
+}
+
+func TestReportSubscriptionErrorDoesNotBlockWithoutAListener(t *testing.T) {
+	r := &marathonClient{eventsErrorChan: make(chan error)}
+
+	done := make(chan struct{})
+	go func() {
+		r.reportSubscriptionError(ErrMarathonDown)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("reportSubscriptionError() blocked with no listener on eventsErrorChan")
+	}
+}
+
+func TestRemoveEventsListenerCancelsSubscriptionWhenEmpty(t *testing.T) {
+	cancelled := false
+	r := &marathonClient{
+		listeners:          map[EventsChannel]int{make(EventsChannel): 0},
+		subscriptionCancel: func() { cancelled = true },
+	}
+
+	var channel EventsChannel
+	for c := range r.listeners {
+		channel = c
+	}
+
+	r.RemoveEventsListener(channel)
+
+	if !cancelled {
+		t.Fatal("expected RemoveEventsListener to cancel the subscription once the last listener is removed")
+	}
+}
+
+func TestRemoveEventsListenerLeavesSubscriptionRunningWhileListenersRemain(t *testing.T) {
+	cancelled := false
+	a, b := make(EventsChannel), make(EventsChannel)
+	r := &marathonClient{
+		listeners:          map[EventsChannel]int{a: 0, b: 0},
+		subscriptionCancel: func() { cancelled = true },
+	}
+
+	r.RemoveEventsListener(a)
+
+	if cancelled {
+		t.Fatal("RemoveEventsListener should not cancel the subscription while other listeners remain")
+	}
+}
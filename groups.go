@@ -0,0 +1,121 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Group is a hierarchical grouping of applications and sub-groups
+type Group struct {
+	ID           string         `json:"id"`
+	Apps         []*Application `json:"apps,omitempty"`
+	Groups       []*Group       `json:"groups,omitempty"`
+	Dependencies []string       `json:"dependencies,omitempty"`
+}
+
+// Groups is the top level collection of groups returned by the v2/groups endpoint
+type Groups struct {
+	Group
+}
+
+// Groups retrieves the group tree from marathon. Pass one or more EmbedOption values
+// (e.g. EmbedGroupsApps) to inline extra per-application detail on nested apps.
+func (r *marathonClient) Groups(embeds ...EmbedOption) (*Groups, error) {
+	v := addEmbeds(nil, embeds)
+
+	uri := marathonAPIGroups
+	if v != nil {
+		uri = uri + "?" + v.Encode()
+	}
+
+	groups := new(Groups)
+	if err := r.apiGet(uri, nil, groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// Group retrieves a specific group by id. Pass one or more EmbedOption values to inline
+// extra per-application detail on the group's nested apps.
+func (r *marathonClient) Group(name string, embeds ...EmbedOption) (*Group, error) {
+	return r.GroupCtx(context.Background(), name, embeds...)
+}
+
+// GroupCtx is the context-aware sibling of Group
+func (r *marathonClient) GroupCtx(ctx context.Context, name string, embeds ...EmbedOption) (*Group, error) {
+	v := addEmbeds(nil, embeds)
+
+	uri := fmt.Sprintf("%s/%s", marathonAPIGroups, name)
+	if v != nil {
+		uri = uri + "?" + v.Encode()
+	}
+
+	group := new(Group)
+	if err := r.apiGetCtx(ctx, uri, nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// HasGroup checks whether a group with the given id exists in marathon
+func (r *marathonClient) HasGroup(name string) (bool, error) {
+	return r.hasGroupCtx(context.Background(), name)
+}
+
+// hasGroupCtx is the context-aware implementation shared by HasGroup and WaitOnGroupCtx, so a
+// cancelled/expired ctx aborts the in-flight HTTP read instead of only interrupting the poll
+// ticker between attempts
+func (r *marathonClient) hasGroupCtx(ctx context.Context, name string) (bool, error) {
+	_, err := r.GroupCtx(ctx, name)
+	if err == ErrDoesNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// WaitOnGroup waits for a group to become available, or until timeout elapses
+func (r *marathonClient) WaitOnGroup(name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return r.WaitOnGroupCtx(ctx, name)
+}
+
+// WaitOnGroupCtx is the context-aware sibling of WaitOnGroup; it polls until the group is
+// reported present or ctx is cancelled/expires
+func (r *marathonClient) WaitOnGroupCtx(ctx context.Context, name string) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		found, err := r.hasGroupCtx(ctx, name)
+		if err == nil && found {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrTimeoutError
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import "net/http"
+
+// Logger is a minimal structured logging interface implemented by adapters for
+// whatever logging pipeline the caller already uses (zap, logrus, an OTel-backed
+// logger, ...). Config.Logger defaults to a no-op implementation.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// noopLogger discards everything; it is the default Config.Logger
+type noopLogger struct{}
+
+func (noopLogger) Debugf(format string, args ...interface{}) {}
+func (noopLogger) Infof(format string, args ...interface{})  {}
+func (noopLogger) Warnf(format string, args ...interface{})  {}
+func (noopLogger) Errorf(format string, args ...interface{}) {}
+
+// redactedHeaders lists the request headers whose values are replaced with "REDACTED"
+// before a request is ever handed to Logger, RequestHook or ResponseHook
+var redactedHeaders = []string{"Authorization", "X-Marathon-Auth-Token"}
+
+// logger returns the configured Logger, falling back to a no-op when the client
+// was built from a bare Config{} literal rather than NewDefaultConfig
+func (r *marathonClient) logger() Logger {
+	if r.config.Logger != nil {
+		return r.config.Logger
+	}
+	return noopLogger{}
+}
+
+// redactHeaders returns a copy of h with any header in redactedHeaders replaced by "REDACTED",
+// safe to hand to Logger output without leaking credentials
+func redactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "REDACTED")
+		}
+	}
+	return redacted
+}
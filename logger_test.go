@@ -0,0 +1,138 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRedactHeadersScrubsSensitiveHeaders(t *testing.T) {
+	h := make(http.Header)
+	h.Set("Authorization", "Basic dXNlcjpwYXNz")
+	h.Set("X-Marathon-Auth-Token", "token=abc123")
+	h.Set("Content-Type", "application/json")
+
+	redacted := redactHeaders(h)
+
+	if redacted.Get("Authorization") != "REDACTED" {
+		t.Fatalf("Authorization = %q, want REDACTED", redacted.Get("Authorization"))
+	}
+	if redacted.Get("X-Marathon-Auth-Token") != "REDACTED" {
+		t.Fatalf("X-Marathon-Auth-Token = %q, want REDACTED", redacted.Get("X-Marathon-Auth-Token"))
+	}
+	if redacted.Get("Content-Type") != "application/json" {
+		t.Fatalf("Content-Type = %q, want it untouched", redacted.Get("Content-Type"))
+	}
+
+	// the original header set must be left alone
+	if h.Get("Authorization") != "Basic dXNlcjpwYXNz" {
+		t.Fatalf("redactHeaders mutated the original header set: %q", h.Get("Authorization"))
+	}
+}
+
+func TestRedactHeadersLeavesHeadersWithoutSensitiveValuesAlone(t *testing.T) {
+	h := make(http.Header)
+	h.Set("Accept", "application/json")
+
+	redacted := redactHeaders(h)
+
+	if redacted.Get("Authorization") != "" {
+		t.Fatalf("unexpected Authorization header: %q", redacted.Get("Authorization"))
+	}
+	if redacted.Get("Accept") != "application/json" {
+		t.Fatalf("Accept = %q, want application/json", redacted.Get("Accept"))
+	}
+}
+
+func TestApiCallRedactsAuthorizationBeforeRequestHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"apps":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	client.config.HTTPBasicAuthUser = "user"
+	client.config.HTTPBasicPassword = "pass"
+
+	var seenAuth string
+	client.config.RequestHook = func(req *http.Request) {
+		seenAuth = req.Header.Get("Authorization")
+	}
+
+	if _, err := client.Applications(nil); err != nil {
+		t.Fatalf("Applications() returned error: %s", err)
+	}
+
+	if seenAuth != "REDACTED" {
+		t.Fatalf("RequestHook saw Authorization = %q, want REDACTED", seenAuth)
+	}
+}
+
+func TestApiCallPassesRealResponseBodyToResponseHook(t *testing.T) {
+	const body = `{"apps":[]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	var seenBody []byte
+	var seenErr error
+	client.config.ResponseHook = func(response *http.Response, respBody []byte, duration time.Duration, err error) {
+		seenBody = respBody
+		seenErr = err
+	}
+
+	if _, err := client.Applications(nil); err != nil {
+		t.Fatalf("Applications() returned error: %s", err)
+	}
+
+	if seenErr != nil {
+		t.Fatalf("ResponseHook saw err = %v, want nil", seenErr)
+	}
+	if string(seenBody) != body {
+		t.Fatalf("ResponseHook saw body = %q, want %q", seenBody, body)
+	}
+}
+
+func TestApiCallPassesTransportErrorToResponseHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	client := newTestClient(t, server)
+	server.Close() // force every request to fail at the transport level
+
+	var called bool
+	var seenErr error
+	client.config.ResponseHook = func(response *http.Response, respBody []byte, duration time.Duration, err error) {
+		called = true
+		seenErr = err
+	}
+
+	if _, err := client.Applications(nil); err == nil {
+		t.Fatal("expected Applications() to return an error once the server is closed")
+	}
+
+	if !called {
+		t.Fatal("expected ResponseHook to be called even when the transport request fails")
+	}
+	if seenErr == nil {
+		t.Fatal("expected ResponseHook to receive the transport error")
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+const (
+	// marathonAPIPing is the marathon ping endpoint
+	marathonAPIPing = "ping"
+	// marathonAPIApps is the marathon applications endpoint
+	marathonAPIApps = "v2/apps"
+	// marathonAPIDeployments is the marathon deployments endpoint
+	marathonAPIDeployments = "v2/deployments"
+	// marathonAPITasks is the marathon cluster-wide tasks endpoint
+	marathonAPITasks = "v2/tasks"
+	// marathonAPIGroups is the marathon groups endpoint
+	marathonAPIGroups = "v2/groups"
+	// marathonAPIEventStream is the marathon SSE event stream endpoint
+	marathonAPIEventStream = "v2/events"
+	// marathonAPISubscription is the marathon callback subscription endpoint
+	marathonAPISubscription = "v2/eventSubscriptions"
+)
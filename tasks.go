@@ -0,0 +1,61 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import "net/url"
+
+// Task is a single running (or staged) instance of an application
+type Task struct {
+	ID        string `json:"id"`
+	AppID     string `json:"appId"`
+	Host      string `json:"host"`
+	Ports     []int  `json:"ports,omitempty"`
+	StagedAt  string `json:"stagedAt,omitempty"`
+	StartedAt string `json:"startedAt,omitempty"`
+	Version   string `json:"version,omitempty"`
+}
+
+// Tasks is a collection of tasks as returned by the v2/tasks endpoint
+type Tasks struct {
+	Tasks []*Task `json:"tasks"`
+}
+
+// Tasks retrieves the tasks running for a specific application
+func (r *marathonClient) Tasks(application string) (*Tasks, error) {
+	tasks := new(Tasks)
+	if err := r.apiGet(marathonAPIApps+"/"+application+"/tasks", nil, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// AllTasks retrieves every task known to marathon, across all applications. Pass one or
+// more EmbedOption values to inline extra per-application detail alongside the listing.
+func (r *marathonClient) AllTasks(v url.Values, embeds ...EmbedOption) (*Tasks, error) {
+	v = addEmbeds(v, embeds)
+
+	uri := marathonAPITasks
+	if v != nil {
+		uri = uri + "?" + v.Encode()
+	}
+
+	tasks := new(Tasks)
+	if err := r.apiGet(uri, nil, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
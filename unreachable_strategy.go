@@ -0,0 +1,93 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import "encoding/json"
+
+// unreachableStrategyDisabled is the wire value marathon sends/expects when
+// unreachable instance handling is turned off entirely
+const unreachableStrategyDisabled = "disabled"
+
+// UnreachableStrategy configures how marathon handles tasks whose agent becomes
+// unreachable. Marathon serializes this either as a JSON object carrying the
+// two duration fields below, or as the bare string "disabled" -- Disabled
+// tracks which form applies.
+type UnreachableStrategy struct {
+	// Disabled is true when this strategy was (de)serialized from the "disabled" string form
+	Disabled bool `json:"-"`
+	// InactiveAfterSeconds is how long a task may be unreachable before it is considered inactive
+	InactiveAfterSeconds float64 `json:"inactiveAfterSeconds,omitempty"`
+	// ExpungeAfterSeconds is how long an inactive task is kept before marathon expunges it
+	ExpungeAfterSeconds float64 `json:"expungeAfterSeconds,omitempty"`
+}
+
+// UnmarshalJSON decodes an UnreachableStrategy from either the "disabled" string form
+// or the {inactiveAfterSeconds, expungeAfterSeconds} object form
+func (u *UnreachableStrategy) UnmarshalJSON(data []byte) error {
+	var asString string
+	if err := json.Unmarshal(data, &asString); err == nil {
+		if asString != unreachableStrategyDisabled {
+			return ErrInvalidResponse
+		}
+		u.Disabled = true
+		u.InactiveAfterSeconds = 0
+		u.ExpungeAfterSeconds = 0
+		return nil
+	}
+
+	type alias UnreachableStrategy
+	var asObject alias
+	if err := json.Unmarshal(data, &asObject); err != nil {
+		return err
+	}
+
+	*u = UnreachableStrategy(asObject)
+	u.Disabled = false
+	return nil
+}
+
+// MarshalJSON re-emits the form the strategy was constructed in: the bare
+// "disabled" string when Disabled is set, otherwise the object form
+func (u UnreachableStrategy) MarshalJSON() ([]byte, error) {
+	if u.Disabled {
+		return json.Marshal(unreachableStrategyDisabled)
+	}
+
+	type alias UnreachableStrategy
+	return json.Marshal(alias(u))
+}
+
+// SetUnreachableStrategy sets the inactive/expunge thresholds (in seconds) for the application
+func (r *Application) SetUnreachableStrategy(inactiveAfterSeconds, expungeAfterSeconds float64) *Application {
+	r.UnreachableStrategy = &UnreachableStrategy{
+		InactiveAfterSeconds: inactiveAfterSeconds,
+		ExpungeAfterSeconds:  expungeAfterSeconds,
+	}
+	return r
+}
+
+// DisableUnreachableStrategy turns off unreachable instance handling for the application
+func (r *Application) DisableUnreachableStrategy() *Application {
+	r.UnreachableStrategy = &UnreachableStrategy{Disabled: true}
+	return r
+}
+
+// SetKillSelection sets which of an application's tasks are killed first on scale down/restart
+func (r *Application) SetKillSelection(selection KillSelection) *Application {
+	r.KillSelection = selection
+	return r
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2014 Rohith All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package marathon
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnreachableStrategyUnmarshalObjectForm(t *testing.T) {
+	var u UnreachableStrategy
+	data := []byte(`{"inactiveAfterSeconds":60,"expungeAfterSeconds":120}`)
+	if err := json.Unmarshal(data, &u); err != nil {
+		t.Fatalf("Unmarshal() returned error: %s", err)
+	}
+
+	if u.Disabled {
+		t.Fatal("Disabled should be false for the object form")
+	}
+	if u.InactiveAfterSeconds != 60 || u.ExpungeAfterSeconds != 120 {
+		t.Fatalf("unexpected fields after Unmarshal: %+v", u)
+	}
+}
+
+func TestUnreachableStrategyUnmarshalDisabledForm(t *testing.T) {
+	var u UnreachableStrategy
+	if err := json.Unmarshal([]byte(`"disabled"`), &u); err != nil {
+		t.Fatalf("Unmarshal() returned error: %s", err)
+	}
+
+	if !u.Disabled {
+		t.Fatal("Disabled should be true for the \"disabled\" string form")
+	}
+	if u.InactiveAfterSeconds != 0 || u.ExpungeAfterSeconds != 0 {
+		t.Fatalf("durations should be zeroed for the disabled form, got: %+v", u)
+	}
+}
+
+func TestUnreachableStrategyUnmarshalInvalidString(t *testing.T) {
+	var u UnreachableStrategy
+	if err := json.Unmarshal([]byte(`"bogus"`), &u); err != ErrInvalidResponse {
+		t.Fatalf("expected ErrInvalidResponse for an unrecognised string form, got: %v", err)
+	}
+}
+
+func TestUnreachableStrategyMarshalObjectForm(t *testing.T) {
+	u := UnreachableStrategy{InactiveAfterSeconds: 60, ExpungeAfterSeconds: 120}
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %s", err)
+	}
+
+	var roundtrip UnreachableStrategy
+	if err := json.Unmarshal(data, &roundtrip); err != nil {
+		t.Fatalf("failed to unmarshal the marshalled object form: %s", err)
+	}
+	if roundtrip != u {
+		t.Fatalf("round trip mismatch: got %+v, want %+v", roundtrip, u)
+	}
+}
+
+func TestUnreachableStrategyMarshalDisabledForm(t *testing.T) {
+	u := UnreachableStrategy{Disabled: true}
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		t.Fatalf("Marshal() returned error: %s", err)
+	}
+	if string(data) != `"disabled"` {
+		t.Fatalf("Marshal() = %s, want \"disabled\"", data)
+	}
+}
+
+func TestDisableUnreachableStrategy(t *testing.T) {
+	app := new(Application).DisableUnreachableStrategy()
+
+	if app.UnreachableStrategy == nil || !app.UnreachableStrategy.Disabled {
+		t.Fatalf("expected DisableUnreachableStrategy to set Disabled, got: %+v", app.UnreachableStrategy)
+	}
+}
+
+func TestSetUnreachableStrategy(t *testing.T) {
+	app := new(Application).SetUnreachableStrategy(60, 120)
+
+	if app.UnreachableStrategy == nil {
+		t.Fatal("expected UnreachableStrategy to be set")
+	}
+	if app.UnreachableStrategy.InactiveAfterSeconds != 60 || app.UnreachableStrategy.ExpungeAfterSeconds != 120 {
+		t.Fatalf("unexpected fields: %+v", app.UnreachableStrategy)
+	}
+}